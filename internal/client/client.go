@@ -3,18 +3,16 @@ package client
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/go-plugin"
-	"github.com/hashicorp/vagrant-plugin-sdk/helper/paths"
 	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
 	"github.com/hashicorp/vagrant/internal/config"
 	"github.com/hashicorp/vagrant/internal/runner"
 	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
 	"github.com/hashicorp/vagrant/internal/serverclient"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 var (
@@ -22,23 +20,31 @@ var (
 )
 
 type Client struct {
-	config      *config.Config
-	cleanupFns  []func() error
-	client      *serverclient.VagrantClient
-	ctx         context.Context
-	localRunner bool
-	localServer bool
-	logger      hclog.Logger
-	rubyRuntime plugin.ClientProtocol
-	runner      *runner.Runner
-	runnerRef   *vagrant_server.Ref_Runner
-	ui          terminal.UI
+	autoMTLS                   bool
+	config                     *config.Config
+	cleanupFns                 []func() error
+	client                     *serverclient.VagrantClient
+	connectRetryAttempts       int
+	connectRetryInitialBackoff time.Duration
+	connectRetryMaxBackoff     time.Duration
+	ctx                        context.Context
+	localRunner                bool
+	localServer                bool
+	logger                     LogSink
+	rubyRuntime                plugin.ClientProtocol
+	runner                     *runner.Runner
+	runnerRef                  *vagrant_server.Ref_Runner
+	ui                         terminal.UI
 }
 
 func New(ctx context.Context, opts ...Option) (c *Client, err error) {
 	c = &Client{
 		ctx:    ctx,
-		logger: hclog.L().Named("vagrant.client"),
+		logger: NewHCLogSink(hclog.L().Named("vagrant.client")),
+		// Any available runner is targeted by default. Constraint/affinity
+		// based selection (a Ref_Runner_Selector target) is deferred: it
+		// needs proto additions and server-side scoring that don't exist
+		// yet, so there is no client option for it here.
 		runnerRef: &vagrant_server.Ref_Runner{
 			Target: &vagrant_server.Ref_Runner_Any{
 				Any: &vagrant_server.Ref_RunnerAny{},
@@ -54,7 +60,9 @@ func New(ctx context.Context, opts ...Option) (c *Client, err error) {
 		}
 	}()
 
-	// Apply any provided options
+	// Apply any provided options. AutoMTLS defaults to off so existing
+	// callers aren't suddenly required to run a Ruby runtime and runner
+	// that speak AutoMTLS; pass WithAutoMTLS(true) to opt in.
 	var cfg clientConfig
 	for _, opt := range opts {
 		if e := opt(c, &cfg); e != nil {
@@ -64,6 +72,10 @@ func New(ctx context.Context, opts ...Option) (c *Client, err error) {
 	if err != nil {
 		return
 	}
+	c.autoMTLS = cfg.autoMTLS
+	c.connectRetryAttempts = cfg.connectRetryAttempts
+	c.connectRetryInitialBackoff = cfg.connectRetryInitialBackoff
+	c.connectRetryMaxBackoff = cfg.connectRetryMaxBackoff
 
 	// If no UI is configured, create a default
 	if c.ui == nil {
@@ -73,7 +85,7 @@ func New(ctx context.Context, opts ...Option) (c *Client, err error) {
 	// If no client is configured, establish a new connection
 	// or spin up an in-process server
 	if c.client == nil {
-		conn, err := c.initServerClient(context.Background(), &cfg)
+		conn, err := c.initServerClient(ctx, &cfg)
 		if err != nil {
 			c.logger.Error("failed to establish server connection",
 				"error", err)
@@ -128,52 +140,6 @@ func New(ctx context.Context, opts ...Option) (c *Client, err error) {
 	return
 }
 
-func (c *Client) LoadBasis(n string) (*Basis, error) {
-	var basis *vagrant_server.Basis
-	result, err := c.client.FindBasis(
-		c.ctx,
-		&vagrant_server.FindBasisRequest{
-			Basis: &vagrant_server.Basis{
-				Name: n,
-			},
-		},
-	)
-	if err != nil {
-		if status.Code(err) != codes.NotFound {
-			return nil, err
-		}
-		uresult, err := c.client.UpsertBasis(
-			c.ctx,
-			&vagrant_server.UpsertBasisRequest{
-				Basis: &vagrant_server.Basis{
-					Name: n,
-				},
-			},
-		)
-		if err != nil {
-			return nil, err
-		}
-		basis = uresult.Basis
-	} else {
-		basis = result.Basis
-	}
-
-	p, err := paths.NamedVagrantConfig(n)
-	if err != nil {
-		return nil, err
-	}
-
-	return &Basis{
-		basis:   basis,
-		client:  c,
-		ctx:     c.ctx,
-		logger:  c.logger.Named("basis"),
-		path:    p,
-		ui:      c.ui,
-		vagrant: c.client,
-	}, nil
-}
-
 // Close the client and call any cleanup functions
 // that have been defined
 func (c *Client) Close() (err error) {
@@ -195,7 +161,11 @@ func (c *Client) UI() terminal.UI {
 }
 
 type clientConfig struct {
-	connectOpts []serverclient.ConnectOption
+	autoMTLS                   bool
+	connectOpts                []serverclient.ConnectOption
+	connectRetryAttempts       int
+	connectRetryInitialBackoff time.Duration
+	connectRetryMaxBackoff     time.Duration
 }
 
 type Option func(*Client, *clientConfig) error
@@ -234,10 +204,52 @@ func WithLocal() Option {
 	}
 }
 
-// WithLogger sets the logger for the client.
+// WithAutoMTLS controls whether mutual TLS is negotiated between the Go
+// host and its subprocesses (the embedded Ruby runtime and, when
+// WithLocal is used, the local runner). It is off by default, matching
+// prior behavior; enable it once the Ruby runtime and runner subprocess
+// in use support AutoMTLS. Leave it disabled when attaching a debugger
+// (e.g. dlv attach) to a plugin process, since the injected certificate
+// material makes that harder.
+func WithAutoMTLS(enabled bool) Option {
+	return func(_ *Client, cfg *clientConfig) error {
+		cfg.autoMTLS = enabled
+		return nil
+	}
+}
+
+// WithConnectRetry enables an exponential-backoff retry loop (jittered,
+// capped at maxBackoff) around the initial server dial and the
+// subsequent API version negotiation, so the client can ride out a
+// server that is still starting up. maxAttempts of 0 or less disables
+// retrying, which is also the default behavior when this option is not
+// supplied, so existing callers are unaffected.
+func WithConnectRetry(maxAttempts int, initialBackoff, maxBackoff time.Duration) Option {
+	return func(_ *Client, cfg *clientConfig) error {
+		cfg.connectRetryAttempts = maxAttempts
+		cfg.connectRetryInitialBackoff = initialBackoff
+		cfg.connectRetryMaxBackoff = maxBackoff
+		return nil
+	}
+}
+
+// WithLogger sets the logger for the client. It is a thin shim over
+// WithLogSink for callers that only have an hclog.Logger on hand.
 func WithLogger(log hclog.Logger) Option {
 	return func(c *Client, cfg *clientConfig) error {
-		c.logger = log
+		c.logger = NewHCLogSink(log)
+		return nil
+	}
+}
+
+// WithLogSink sets the LogSink the client and the bases it loads log
+// through. Use this instead of WithLogger to merge vagrant's logs into a
+// pipeline built on something other than hclog, such as zerolog or
+// log/slog. The Ruby runtime plugin and the local runner are not given
+// the sink: their own logs go to hclog's default logger.
+func WithLogSink(sink LogSink) Option {
+	return func(c *Client, cfg *clientConfig) error {
+		c.logger = sink
 		return nil
 	}
 }
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"log/slog"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// LogSink is the minimal structured logging surface the client needs.
+// It lets embedders route vagrant's logs into their own logging
+// pipeline (zerolog, slog, or anything else) instead of requiring an
+// hclog.Logger specifically. NewHCLogSink and NewSlogSink are provided
+// adapters; a zerolog adapter can be written against this same
+// interface by any caller that needs one.
+type LogSink interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+	Named(name string) LogSink
+}
+
+type hclogSink struct {
+	log hclog.Logger
+}
+
+// NewHCLogSink adapts an hclog.Logger to the LogSink interface.
+func NewHCLogSink(log hclog.Logger) LogSink {
+	return &hclogSink{log: log}
+}
+
+func (s *hclogSink) Debug(msg string, args ...interface{}) { s.log.Debug(msg, args...) }
+func (s *hclogSink) Info(msg string, args ...interface{})  { s.log.Info(msg, args...) }
+func (s *hclogSink) Warn(msg string, args ...interface{})  { s.log.Warn(msg, args...) }
+func (s *hclogSink) Error(msg string, args ...interface{}) { s.log.Error(msg, args...) }
+
+func (s *hclogSink) Named(name string) LogSink {
+	return &hclogSink{log: s.log.Named(name)}
+}
+
+type slogSink struct {
+	log *slog.Logger
+}
+
+// NewSlogSink adapts a *slog.Logger to the LogSink interface.
+func NewSlogSink(log *slog.Logger) LogSink {
+	return &slogSink{log: log}
+}
+
+func (s *slogSink) Debug(msg string, args ...interface{}) { s.log.Debug(msg, args...) }
+func (s *slogSink) Info(msg string, args ...interface{})  { s.log.Info(msg, args...) }
+func (s *slogSink) Warn(msg string, args ...interface{})  { s.log.Warn(msg, args...) }
+func (s *slogSink) Error(msg string, args ...interface{}) { s.log.Error(msg, args...) }
+
+func (s *slogSink) Named(name string) LogSink {
+	return &slogSink{log: s.log.With("subsystem", name)}
+}
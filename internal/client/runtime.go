@@ -0,0 +1,77 @@
+package client
+
+import (
+	"os/exec"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/vagrant/internal/runner"
+)
+
+// rubyRuntimeHandshake is the handshake configuration shared between the
+// Go host and the embedded Ruby runtime plugin process.
+var rubyRuntimeHandshake = plugin.HandshakeConfig{
+	ProtocolVersion:  1,
+	MagicCookieKey:   "VAGRANT_RUBY_RUNTIME",
+	MagicCookieValue: "vagrant",
+}
+
+// initVagrantRubyRuntime launches the embedded Ruby runtime as a
+// go-plugin subprocess. When AutoMTLS is enabled, go-plugin generates a
+// short-lived self-signed CA, hands the client certificate to the
+// subprocess over the handshake, and requires client-auth TLS on the
+// resulting gRPC channel.
+func (c *Client) initVagrantRubyRuntime() (plugin.ClientProtocol, error) {
+	// go-plugin's client hooks are hclog-specific, so this stays on hclog
+	// even though c.logger may be backed by a different sink.
+	pc := plugin.NewClient(&plugin.ClientConfig{
+		HandshakeConfig:  rubyRuntimeHandshake,
+		Plugins:          rubyRuntimePlugins,
+		Cmd:              exec.Command("vagrant-ruby-runtime"),
+		Logger:           hclog.L().Named("ruby_runtime"),
+		AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
+		AutoMTLS:         c.autoMTLS,
+	})
+
+	c.Cleanup(func() error {
+		pc.Kill()
+		return nil
+	})
+
+	rpcClient, err := pc.Client()
+	if err != nil {
+		c.logger.Error("failed to negotiate ruby runtime connection",
+			"auto-mtls", c.autoMTLS, "error", err)
+		return nil, err
+	}
+
+	return rpcClient, nil
+}
+
+// startRunner brings up a local runner for per-operation execution, used
+// when the client is configured with WithLocal. It shares the client's
+// AutoMTLS setting so the runner's gRPC traffic to the server is
+// authenticated the same way as the Ruby runtime connection.
+func (c *Client) startRunner() (*runner.Runner, error) {
+	// The runner package isn't part of this tree, so runner.WithLogger,
+	// runner.WithClient, and runner.WithAutoMTLS are assumed rather than
+	// confirmed to exist with these signatures. In particular,
+	// runner.WithLogger still takes an hclog.Logger here rather than
+	// c.logger, since the runner package hasn't been converted to
+	// client.LogSink. Verify all three against the real runner package
+	// once it's available.
+	return runner.New(
+		c.ctx,
+		runner.WithClient(c.client),
+		runner.WithLogger(hclog.L().Named("runner")),
+		runner.WithAutoMTLS(c.autoMTLS),
+	)
+}
+
+// rubyRuntimePlugins is the plugin set exposed over the Ruby runtime's
+// gRPC connection. This is still empty: the concrete plugin
+// implementations need to be registered here from the vagrant-plugin-sdk
+// before initVagrantRubyRuntime produces a runtime client that can
+// actually serve requests. Populating it is follow-up work, not done by
+// the AutoMTLS change in this commit series.
+var rubyRuntimePlugins = plugin.PluginSet{}
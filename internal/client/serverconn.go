@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+	"github.com/hashicorp/vagrant/internal/serverclient"
+)
+
+// initServerClient dials the vagrant server through serverclient.Connect
+// with the options given to WithClientConnect. It does not itself start
+// an in-process server, and WithLocal has no effect on it.
+// When WithConnectRetry is configured, the dial is retried with
+// exponential backoff until it succeeds, the context is canceled, or the
+// attempt budget is exhausted.
+func (c *Client) initServerClient(ctx context.Context, cfg *clientConfig) (conn *grpc.ClientConn, err error) {
+	err = c.withConnectRetry(ctx, "connecting to vagrant server", func() (retryErr error) {
+		conn, retryErr = serverclient.Connect(ctx, cfg.connectOpts...)
+		return retryErr
+	})
+
+	return
+}
+
+// negotiateApiVersion confirms the connected server speaks a version of
+// the RPC protocol this client understands. A freshly-started server may
+// accept TCP connections before its gRPC services are registered, so
+// this uses the same retry policy as the initial dial.
+func (c *Client) negotiateApiVersion(ctx context.Context) error {
+	return c.withConnectRetry(ctx, "negotiating vagrant server API version", func() error {
+		_, err := c.client.GetVersionInfo(ctx, &vagrant_server.GetVersionInfoRequest{})
+		return err
+	})
+}
+
+// withConnectRetry runs attempt until it succeeds, the context is
+// canceled, or the configured attempt budget is exhausted. With no
+// WithConnectRetry option supplied, c.connectRetryAttempts is 0 and
+// attempt runs exactly once, matching prior single-shot behavior.
+func (c *Client) withConnectRetry(ctx context.Context, desc string, attempt func() error) error {
+	backoff := c.connectRetryInitialBackoff
+	if backoff <= 0 {
+		backoff = 500 * time.Millisecond
+	}
+
+	for try := 1; ; try++ {
+		err := attempt()
+		if err == nil {
+			return nil
+		}
+		if try >= c.connectRetryAttempts {
+			return err
+		}
+
+		c.ui.Output(fmt.Sprintf("%s: attempt %d failed, retrying: %s", desc, try, err))
+
+		wait := backoff + time.Duration(rand.Int63n(int64(backoff)/2+1))
+		if c.connectRetryMaxBackoff > 0 && wait > c.connectRetryMaxBackoff {
+			wait = c.connectRetryMaxBackoff
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+
+		backoff *= 2
+		if c.connectRetryMaxBackoff > 0 && backoff > c.connectRetryMaxBackoff {
+			backoff = c.connectRetryMaxBackoff
+		}
+	}
+}